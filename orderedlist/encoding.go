@@ -0,0 +1,281 @@
+package orderedlist
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrCorruptOrder is returned when decoded records are not already
+// sorted in value order, or contain a duplicate key.
+var ErrCorruptOrder = errors.New("orderedlist: encoded records are not in sorted order")
+
+// ErrBadMagic is returned by ReadFrom when the stream does not start
+// with the expected binary format magic bytes.
+var ErrBadMagic = errors.New("orderedlist: bad magic bytes")
+
+// ErrKeyTooLarge is returned by ReadFrom when an encoded key length
+// exceeds maxBinaryKeyLen, guarding against a corrupt or adversarial
+// length field driving an oversized allocation.
+var ErrKeyTooLarge = errors.New("orderedlist: encoded key length exceeds limit")
+
+// binMagic prefixes the binary format written by WriteTo.
+var binMagic = [4]byte{'O', 'L', '0', '1'}
+
+// maxBinaryKeyLen bounds a single decoded key, since the length field
+// read from the stream cannot otherwise be trusted.
+const maxBinaryKeyLen = 1 << 20
+
+// loadRecords resets ol to a list containing recs, which must already
+// be sorted in value order and have no duplicate keys. It keeps ol's
+// existing backend type (slice or tree), defaulting to slice if ol is
+// a zero-value OrderedListG.
+func loadRecords[K comparable, V constraints.Ordered](ol *OrderedListG[K, V], recs []Record[K, V]) error {
+	for i := 1; i < len(recs); i++ {
+		if recs[i].Value < recs[i-1].Value {
+			return ErrCorruptOrder
+		}
+	}
+
+	var store Store[K, V] = newSliceStore[K, V]()
+	if ol.store != nil {
+		store = ol.store.Empty()
+	}
+
+	bookkeeping := make(map[K]*list.Element, len(recs))
+	order := list.New()
+	for _, rec := range recs {
+		if _, dup := bookkeeping[rec.Key]; dup {
+			return ErrCorruptOrder
+		}
+		r := rec
+		bookkeeping[r.Key] = order.PushBack(&r)
+	}
+
+	store.LoadSorted(recs)
+
+	*ol = OrderedListG[K, V]{
+		bookkeeping: bookkeeping,
+		store:       store,
+		order:       order,
+	}
+	return nil
+}
+
+// MarshalJSON encodes the list as a JSON array of records in value
+// order. It has a value receiver, unlike the other methods here, so it
+// is still selected when a list is marshaled by value (json.Marshal
+// can only call pointer-receiver methods on an addressable value).
+func (ol OrderedListG[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ol.store.All())
+}
+
+// UnmarshalJSON decodes a JSON array of records produced by
+// MarshalJSON, rebuilding the list. The existing backend (slice or
+// tree) is preserved; a zero-value OrderedListG decodes into the
+// default slice backend. It returns ErrCorruptOrder if the records are
+// not sorted by value.
+func (ol *OrderedListG[K, V]) UnmarshalJSON(data []byte) error {
+	var recs []Record[K, V]
+	if err := json.Unmarshal(data, &recs); err != nil {
+		return err
+	}
+
+	return loadRecords(ol, recs)
+}
+
+// GobEncode encodes the list as a gob-encoded slice of records in value
+// order. It has a value receiver, unlike the other methods here, so
+// gob can still call it when a list (or a struct embedding one) is
+// encoded by value instead of by pointer; a pointer receiver would
+// make gob fail with "gob: unaddressable value" in that case.
+func (ol OrderedListG[K, V]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(ol.store.All()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode decodes a gob-encoded slice of records produced by
+// GobEncode, rebuilding the list. The existing backend (slice or tree)
+// is preserved; a zero-value OrderedListG decodes into the default
+// slice backend. It returns ErrCorruptOrder if the records are not
+// sorted by value.
+func (ol *OrderedListG[K, V]) GobDecode(data []byte) error {
+	var recs []Record[K, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&recs); err != nil {
+		return err
+	}
+
+	return loadRecords(ol, recs)
+}
+
+// WriteTo writes the list in a compact binary format: a 4-byte magic, a
+// little-endian uint32 record count, then per record a little-endian
+// uint32 key length, the key bytes, and a little-endian uint64 value.
+// It only supports string keys and uint64 values.
+func (ol *OrderedListG[K, V]) WriteTo(w io.Writer) (int64, error) {
+	records := ol.store.All()
+
+	var written int64
+	if err := binary.Write(w, binary.LittleEndian, binMagic); err != nil {
+		return written, err
+	}
+	written += int64(len(binMagic))
+
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(records))); err != nil {
+		return written, err
+	}
+	written += 4
+
+	for _, rec := range records {
+		key, ok := any(rec.Key).(string)
+		if !ok {
+			return written, fmt.Errorf("orderedlist: binary encoding only supports string keys, got %T", rec.Key)
+		}
+		value, ok := any(rec.Value).(uint64)
+		if !ok {
+			return written, fmt.Errorf("orderedlist: binary encoding only supports uint64 values, got %T", rec.Value)
+		}
+
+		if err := binary.Write(w, binary.LittleEndian, uint32(len(key))); err != nil {
+			return written, err
+		}
+		written += 4
+
+		n, err := io.WriteString(w, key)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+
+		if err := binary.Write(w, binary.LittleEndian, value); err != nil {
+			return written, err
+		}
+		written += 8
+	}
+
+	return written, nil
+}
+
+// ReadFrom reads the binary format written by WriteTo, rebuilding the
+// list. The existing backend (slice or tree) is preserved; a zero-value
+// OrderedListG decodes into the default slice backend. It only supports
+// string keys and uint64 values, and returns ErrBadMagic or
+// ErrCorruptOrder if the stream is malformed.
+func (ol *OrderedListG[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	var read int64
+
+	var magic [4]byte
+	n, err := io.ReadFull(r, magic[:])
+	read += int64(n)
+	if err != nil {
+		return read, err
+	}
+	if magic != binMagic {
+		return read, ErrBadMagic
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return read, err
+	}
+	read += 4
+
+	recs := make([]Record[K, V], 0, min(int(count), 4096))
+	for i := uint32(0); i < count; i++ {
+		var keyLen uint32
+		if err := binary.Read(r, binary.LittleEndian, &keyLen); err != nil {
+			return read, err
+		}
+		read += 4
+
+		if keyLen > maxBinaryKeyLen {
+			return read, ErrKeyTooLarge
+		}
+
+		keyBytes := make([]byte, keyLen)
+		n, err := io.ReadFull(r, keyBytes)
+		read += int64(n)
+		if err != nil {
+			return read, err
+		}
+
+		var value uint64
+		if err := binary.Read(r, binary.LittleEndian, &value); err != nil {
+			return read, err
+		}
+		read += 8
+
+		key, ok := any(string(keyBytes)).(K)
+		if !ok {
+			var zero K
+			return read, fmt.Errorf("orderedlist: binary decoding only supports string keys, got %T", zero)
+		}
+		val, ok := any(value).(V)
+		if !ok {
+			var zero V
+			return read, fmt.Errorf("orderedlist: binary decoding only supports uint64 values, got %T", zero)
+		}
+
+		recs = append(recs, Record[K, V]{Key: key, Value: val})
+	}
+
+	return read, loadRecords(ol, recs)
+}
+
+// SaveFile writes the list to path using the binary format. It writes
+// to a temporary file in the same directory and renames it into place,
+// so a failure partway through WriteTo (e.g. an unsupported key or
+// value type) leaves any existing file at path untouched.
+func (ol *OrderedListG[K, V]) SaveFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// os.CreateTemp uses mode 0600; match the mode of any file already at
+	// path, or 0644 for a new file, so SaveFile doesn't silently tighten
+	// permissions on an existing file.
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := ol.WriteTo(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadFile reads the list from path using the binary format.
+func (ol *OrderedListG[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = ol.ReadFrom(f)
+	return err
+}
@@ -0,0 +1,213 @@
+package orderedlist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func buildTestList(t *testing.T, backend func() OrderedList) OrderedList {
+	t.Helper()
+
+	l := backend()
+	for i, v := range []uint64{5, 3, 8, 1, 9, 2, 7} {
+		if err := l.Insert(fmt.Sprintf("k%d", i), v); err != nil {
+			t.Fatalf("Insert: %v", err)
+		}
+	}
+	return l
+}
+
+func assertSameRecords(t *testing.T, got, want *OrderedList) {
+	t.Helper()
+
+	if got.Len() != want.Len() {
+		t.Fatalf("Len() = %d, want %d", got.Len(), want.Len())
+	}
+	for i := 0; i < want.Len(); i++ {
+		if g, w := got.At(i), want.At(i); g != w {
+			t.Fatalf("At(%d) = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	l := buildTestList(t, New)
+
+	data, err := json.Marshal(&l)
+	if err != nil {
+		t.Fatalf("MarshalJSON: %v", err)
+	}
+
+	var decoded OrderedList
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+
+	assertSameRecords(t, &decoded, &l)
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	l := buildTestList(t, New)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&l); err != nil {
+		t.Fatalf("gob encode: %v", err)
+	}
+
+	var decoded OrderedList
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("gob decode: %v", err)
+	}
+
+	assertSameRecords(t, &decoded, &l)
+}
+
+// TestMarshalByValue guards against a regression where an exported
+// bookkeeping field would shadow the MarshalJSON/GobEncode pointer
+// receivers when a list is encoded by value: json would serialize the
+// internals instead of the record array, and gob would fail outright
+// with "gob: unaddressable value".
+func TestMarshalByValue(t *testing.T) {
+	l := buildTestList(t, New)
+
+	data, err := json.Marshal(l)
+	if err != nil {
+		t.Fatalf("json.Marshal(by value): %v", err)
+	}
+
+	var decoded OrderedList
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON: %v", err)
+	}
+	assertSameRecords(t, &decoded, &l)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(l); err != nil {
+		t.Fatalf("gob.Encode(by value): %v", err)
+	}
+
+	var gobDecoded OrderedList
+	if err := gob.NewDecoder(&buf).Decode(&gobDecoded); err != nil {
+		t.Fatalf("gob decode: %v", err)
+	}
+	assertSameRecords(t, &gobDecoded, &l)
+}
+
+// TestMarshalEmbeddedByValue exercises the same by-value encoding path
+// through a wrapper struct, which is how gob most commonly trips over
+// an unaddressable field.
+func TestMarshalEmbeddedByValue(t *testing.T) {
+	type wrapper struct {
+		Name string
+		List OrderedList
+	}
+
+	w := wrapper{Name: "scores", List: buildTestList(t, New)}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(w); err != nil {
+		t.Fatalf("gob.Encode(wrapper by value): %v", err)
+	}
+
+	var decoded wrapper
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("gob decode: %v", err)
+	}
+	if decoded.Name != w.Name {
+		t.Fatalf("Name = %q, want %q", decoded.Name, w.Name)
+	}
+	assertSameRecords(t, &decoded.List, &w.List)
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	l := buildTestList(t, New)
+
+	var buf bytes.Buffer
+	if _, err := l.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	var decoded OrderedList
+	if _, err := decoded.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+
+	assertSameRecords(t, &decoded, &l)
+}
+
+func TestBinaryRoundTripPreservesTreeBackend(t *testing.T) {
+	l := buildTestList(t, NewTree)
+
+	var buf bytes.Buffer
+	if _, err := l.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	// Decode into l itself (already tree-backed), as a caller refreshing
+	// an existing list from disk would, not into a fresh zero value.
+	if _, err := l.ReadFrom(&buf); err != nil {
+		t.Fatalf("ReadFrom: %v", err)
+	}
+
+	if _, ok := l.store.(*rbStore[string, uint64]); !ok {
+		t.Fatalf("list backend after decode = %T, want *rbStore", l.store)
+	}
+}
+
+func TestReadFromRejectsBadMagic(t *testing.T) {
+	var decoded OrderedList
+	_, err := decoded.ReadFrom(bytes.NewReader([]byte("not a valid stream!!")))
+	if err != ErrBadMagic {
+		t.Fatalf("ReadFrom() error = %v, want ErrBadMagic", err)
+	}
+}
+
+func TestReadFromRejectsDuplicateKeys(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(binMagic[:])
+	binary.Write(&buf, binary.LittleEndian, uint32(2))
+	for i := 0; i < 2; i++ {
+		binary.Write(&buf, binary.LittleEndian, uint32(3))
+		buf.WriteString("dup")
+		binary.Write(&buf, binary.LittleEndian, uint64(1))
+	}
+
+	var decoded OrderedList
+	_, err := decoded.ReadFrom(&buf)
+	if err != ErrCorruptOrder {
+		t.Fatalf("ReadFrom() error = %v, want ErrCorruptOrder", err)
+	}
+}
+
+func TestReadFromRejectsOversizedKeyLength(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write(binMagic[:])
+	binary.Write(&buf, binary.LittleEndian, uint32(1))
+	binary.Write(&buf, binary.LittleEndian, uint32(0xFFFFFFFF))
+
+	var decoded OrderedList
+	_, err := decoded.ReadFrom(&buf)
+	if err != ErrKeyTooLarge {
+		t.Fatalf("ReadFrom() error = %v, want ErrKeyTooLarge", err)
+	}
+}
+
+func TestSaveLoadFile(t *testing.T) {
+	l := buildTestList(t, New)
+	path := t.TempDir() + "/list.bin"
+
+	if err := l.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile: %v", err)
+	}
+
+	var decoded OrderedList
+	if err := decoded.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+
+	assertSameRecords(t, &decoded, &l)
+}
@@ -1,132 +1,224 @@
 /*
-	Package for storing pairs of key-value where the keys are of type string
-	and the values are of type 64-bit unsigned integer. The list of pairs are ordered
-	starting from the lowest value down to the highest.
+	Package for storing pairs of key-value where the keys are comparable
+	and the values are ordered. The list of pairs are ordered starting
+	from the lowest value down to the highest.
 */
 package orderedlist
 
 import (
+	"container/list"
 	"errors"
+
+	"golang.org/x/exp/constraints"
 )
 
 type (
-	Record struct {
-		Key   string
-		Value uint64
+	// Record is a single key-value pair stored in an OrderedListG.
+	Record[K comparable, V constraints.Ordered] struct {
+		Key   K
+		Value V
 	}
 
-	OrderedList struct {
-		Bookkeeping map[string]bool
-		Rec         []Record
+	// OrderedListG is the generic form of OrderedList. K is the key type
+	// and V is the value type the list is ordered by. Records are kept
+	// in a Store backend, which New/NewTree/NewWithBackend choose, and
+	// are additionally tracked in insertion order via order/bookkeeping.
+	OrderedListG[K comparable, V constraints.Ordered] struct {
+		bookkeeping map[K]*list.Element
+		store       Store[K, V]
+		order       *list.List
 	}
 )
 
-// New returns new (empty) instance of ordered list
+// OrderedList is the original string-key, uint64-value ordered list, kept
+// as a type alias so existing callers keep working unchanged.
+type OrderedList = OrderedListG[string, uint64]
+
+// New returns new (empty) instance of ordered list backed by a plain
+// slice. It is the cheapest backend for small lists.
 func New() OrderedList {
-	return OrderedList{
-		Bookkeeping: make(map[string]bool),
+	return NewG[string, uint64]()
+}
+
+// NewTree returns a new (empty) instance of ordered list backed by a
+// red-black tree, giving O(log n) Insert/Remove/Get instead of the
+// slice backend's O(n).
+func NewTree() OrderedList {
+	return NewTreeG[string, uint64]()
+}
+
+// NewG returns a new (empty) slice-backed instance of a generic ordered
+// list for the given key and value types.
+func NewG[K comparable, V constraints.Ordered]() OrderedListG[K, V] {
+	return NewWithBackendG[K, V](newSliceStore[K, V]())
+}
+
+// NewTreeG returns a new (empty) red-black tree-backed instance of a
+// generic ordered list for the given key and value types.
+func NewTreeG[K comparable, V constraints.Ordered]() OrderedListG[K, V] {
+	return NewWithBackendG[K, V](newRBStore[K, V]())
+}
+
+// NewWithBackendG returns a new (empty) generic ordered list using the
+// given Store backend.
+func NewWithBackendG[K comparable, V constraints.Ordered](store Store[K, V]) OrderedListG[K, V] {
+	return OrderedListG[K, V]{
+		bookkeeping: make(map[K]*list.Element),
+		store:       store,
+		order:       list.New(),
 	}
 }
 
 // Insert inserts a key-value pair into the struct
-func (ol *OrderedList) Insert(key string, value uint64) error {
-	exists := ol.Bookkeeping[key]
-	if exists {
+func (ol *OrderedListG[K, V]) Insert(key K, value V) error {
+	if _, exists := ol.bookkeeping[key]; exists {
 		return errors.New("key already exists")
 	}
 
-	// Get proper index
-	index := ol.getPosition(value)
-
-	if index == -1 {
-		// Append to the bottom of the list
-		ol.Rec = append(ol.Rec, Record{key, value})
-	} else {
-		// Append accordingly
-		ol.Rec = append(ol.Rec, Record{})
-		copy(ol.Rec[index+1:], ol.Rec[index:])
-		ol.Rec[index] = Record{key, value}
-	}
-
-	ol.Bookkeeping[key] = true
+	ol.store.Insert(key, value)
+	ol.bookkeeping[key] = ol.order.PushBack(&Record[K, V]{Key: key, Value: value})
 	return nil
 }
 
 // Get returns value of the provided key
-func (ol *OrderedList) Get(key string) (uint64, error) {
-	index, err := ol.getIndexByKey(key)
-	if err != nil {
-		return 0, err
+func (ol *OrderedListG[K, V]) Get(key K) (V, error) {
+	value, ok := ol.store.Get(key)
+	if !ok {
+		var zero V
+		return zero, errors.New("key does not exists")
 	}
 
-	return ol.Rec[index].Value, nil
+	return value, nil
+}
+
+// GetLowest returns the value of the record with the lowest value on
+// the list, or false if the list is empty.
+func (ol *OrderedListG[K, V]) GetLowest() (V, bool) {
+	record, ok := ol.store.GetLowest()
+	return record.Value, ok
+}
+
+// GetHighest returns the value of the record with the highest value on
+// the list, or false if the list is empty.
+func (ol *OrderedListG[K, V]) GetHighest() (V, bool) {
+	record, ok := ol.store.GetHighest()
+	return record.Value, ok
 }
 
-// GetLowest returns key-value pair with lowest value on the list
-func (ol *OrderedList) GetLowest() uint64 {
-	return ol.Rec[0].Value
+// At returns the record at position i in value order, where i is in
+// [0, Len()).
+func (ol *OrderedListG[K, V]) At(i int) Record[K, V] {
+	return ol.store.At(i)
 }
 
-// GetHighest returns key-value pair with highest value on the list
-func (ol *OrderedList) GetHighest() uint64 {
-	return ol.Rec[len(ol.Rec)-1].Value
+// Len returns the number of key-value pairs on the list
+func (ol *OrderedListG[K, V]) Len() int {
+	return ol.store.Len()
+}
+
+// GetGreater returns the record with the smallest value strictly
+// greater than value, if any.
+func (ol *OrderedListG[K, V]) GetGreater(value V) (Record[K, V], bool) {
+	return ol.store.GetGreater(value)
+}
+
+// GetGreaterThanOrEqual returns the record with the smallest value
+// greater than or equal to value, if any.
+func (ol *OrderedListG[K, V]) GetGreaterThanOrEqual(value V) (Record[K, V], bool) {
+	return ol.store.GetGreaterThanOrEqual(value)
+}
+
+// GetLower returns the record with the largest value strictly lower
+// than value, if any.
+func (ol *OrderedListG[K, V]) GetLower(value V) (Record[K, V], bool) {
+	return ol.store.GetLower(value)
+}
+
+// GetLowerThanOrEqual returns the record with the largest value lower
+// than or equal to value, if any.
+func (ol *OrderedListG[K, V]) GetLowerThanOrEqual(value V) (Record[K, V], bool) {
+	return ol.store.GetLowerThanOrEqual(value)
+}
+
+// Range returns every record with a value between lo and hi, in value
+// order. When inclusive is true both endpoints are included; otherwise
+// both are excluded.
+func (ol *OrderedListG[K, V]) Range(lo, hi V, inclusive bool) []Record[K, V] {
+	return ol.store.Range(lo, hi, inclusive)
+}
+
+// RangeFunc walks every record with a value between lo and hi, in value
+// order, calling fn for each until fn returns false.
+func (ol *OrderedListG[K, V]) RangeFunc(lo, hi V, inclusive bool, fn func(Record[K, V]) bool) {
+	ol.store.RangeFunc(lo, hi, inclusive, fn)
 }
 
 // Remove removes a key-value pair by key
-func (ol *OrderedList) Remove(key string) error {
-	index, err := ol.getIndexByKey(key)
-	if err != nil {
-		return err
+func (ol *OrderedListG[K, V]) Remove(key K) error {
+	elem, exists := ol.bookkeeping[key]
+	if !exists {
+		return errors.New("key does not exists")
 	}
 
-	ol.Rec = append(ol.Rec[:index], ol.Rec[index+1:]...)
-	delete(ol.Bookkeeping, key)
+	ol.store.Remove(key)
+	ol.order.Remove(elem)
+	delete(ol.bookkeeping, key)
 
 	return nil
 }
 
-// Update updates value by key
-func (ol *OrderedList) Update(key string, value uint64) error {
-	if err := ol.Remove(key); err != nil {
-		return err
+// Update updates value by key. Unlike Remove followed by Insert, the
+// record keeps its original insertion-order position.
+func (ol *OrderedListG[K, V]) Update(key K, value V) error {
+	elem, exists := ol.bookkeeping[key]
+	if !exists {
+		return errors.New("key does not exists")
 	}
 
-	if err := ol.Insert(key, value); err != nil {
-		return err
-	}
+	ol.store.Remove(key)
+	ol.store.Insert(key, value)
+	elem.Value.(*Record[K, V]).Value = value
 
 	return nil
 }
 
-// Merge merges list passed to the parameter into the receiver.
-// Source list shall be left unchanged.
-// Duplicate key error will not be reported.
-func (ol *OrderedList) Merge(source *OrderedList) {
-	for _, record := range source.Rec {
-		ol.Insert(record.Key, record.Value)
+// Oldest returns the record that has been on the list the longest, or
+// nil if the list is empty.
+func (ol *OrderedListG[K, V]) Oldest() *Record[K, V] {
+	elem := ol.order.Front()
+	if elem == nil {
+		return nil
 	}
+
+	return elem.Value.(*Record[K, V])
 }
 
-// getPosition returns proper position (index) to be placed into struct
-func (ol *OrderedList) getPosition(value uint64) int {
-	index := -1
-	for i, record := range ol.Rec {
-		if value <= record.Value {
-			index = i
-			break
-		}
+// Newest returns the most recently inserted record, or nil if the list
+// is empty.
+func (ol *OrderedListG[K, V]) Newest() *Record[K, V] {
+	elem := ol.order.Back()
+	if elem == nil {
+		return nil
 	}
 
-	return index
+	return elem.Value.(*Record[K, V])
 }
 
-// getIndexByKey returns index of key-value pair in array by key
-func (ol *OrderedList) getIndexByKey(key string) (int, error) {
-	for i, record := range ol.Rec {
-		if record.Key == key {
-			return i, nil
+// IterateInsertionOrder walks every record in insertion order, oldest
+// first, calling fn for each until fn returns false.
+func (ol *OrderedListG[K, V]) IterateInsertionOrder(fn func(Record[K, V]) bool) {
+	for elem := ol.order.Front(); elem != nil; elem = elem.Next() {
+		if !fn(*elem.Value.(*Record[K, V])) {
+			return
 		}
 	}
+}
 
-	return -1, errors.New("key does not exists")
+// Merge merges list passed to the parameter into the receiver.
+// Source list shall be left unchanged.
+// Duplicate key error will not be reported.
+func (ol *OrderedListG[K, V]) Merge(source *OrderedListG[K, V]) {
+	for _, record := range source.store.All() {
+		ol.Insert(record.Key, record.Value)
+	}
 }
@@ -0,0 +1,575 @@
+package orderedlist
+
+import "golang.org/x/exp/constraints"
+
+// rbColor is the color of a red-black tree node.
+type rbColor bool
+
+const (
+	rbRed   rbColor = true
+	rbBlack rbColor = false
+)
+
+// rbNode is a red-black tree node ordered by (value, seq). seq is an
+// insertion sequence number used to break ties between equal values,
+// since K is only constraints.comparable and cannot be compared with
+// "<".
+type rbNode[K comparable, V constraints.Ordered] struct {
+	key                 K
+	value               V
+	seq                 uint64
+	left, right, parent *rbNode[K, V]
+	color               rbColor
+	size                int
+}
+
+// rbStore is a red-black tree backend for OrderedListG. Insert, Remove
+// and key lookup run in O(log n); GetLowest/GetHighest are O(1) via
+// cached leftmost/rightmost nodes, and At is an O(log n) order-statistic
+// select using each node's subtree size.
+type rbStore[K comparable, V constraints.Ordered] struct {
+	root                *rbNode[K, V]
+	index               map[K]*rbNode[K, V]
+	leftmost, rightmost *rbNode[K, V]
+	seq                 uint64
+}
+
+// newRBStore returns a new, empty red-black tree backend.
+func newRBStore[K comparable, V constraints.Ordered]() *rbStore[K, V] {
+	return &rbStore[K, V]{index: make(map[K]*rbNode[K, V])}
+}
+
+func sizeOf[K comparable, V constraints.Ordered](n *rbNode[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func colorOf[K comparable, V constraints.Ordered](n *rbNode[K, V]) rbColor {
+	if n == nil {
+		return rbBlack
+	}
+	return n.color
+}
+
+func updateSize[K comparable, V constraints.Ordered](n *rbNode[K, V]) {
+	n.size = 1 + sizeOf(n.left) + sizeOf(n.right)
+}
+
+// less reports whether a sorts before b, ordering first by value and
+// then by insertion sequence.
+func rbLess[K comparable, V constraints.Ordered](a, b *rbNode[K, V]) bool {
+	if a.value != b.value {
+		return a.value < b.value
+	}
+	return a.seq < b.seq
+}
+
+func minNode[K comparable, V constraints.Ordered](n *rbNode[K, V]) *rbNode[K, V] {
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func maxNode[K comparable, V constraints.Ordered](n *rbNode[K, V]) *rbNode[K, V] {
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// successor returns the next node in value order, or nil if n is last.
+func successor[K comparable, V constraints.Ordered](n *rbNode[K, V]) *rbNode[K, V] {
+	if n.right != nil {
+		return minNode(n.right)
+	}
+	p := n.parent
+	for p != nil && n == p.right {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+// predecessor returns the previous node in value order, or nil if n is
+// first.
+func predecessor[K comparable, V constraints.Ordered](n *rbNode[K, V]) *rbNode[K, V] {
+	if n.left != nil {
+		return maxNode(n.left)
+	}
+	p := n.parent
+	for p != nil && n == p.left {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+func (t *rbStore[K, V]) rotateLeft(x *rbNode[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	switch {
+	case x.parent == nil:
+		t.root = y
+	case x == x.parent.left:
+		x.parent.left = y
+	default:
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+	updateSize(x)
+	updateSize(y)
+}
+
+func (t *rbStore[K, V]) rotateRight(x *rbNode[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	y.parent = x.parent
+	switch {
+	case x.parent == nil:
+		t.root = y
+	case x == x.parent.right:
+		x.parent.right = y
+	default:
+		x.parent.left = y
+	}
+	y.right = x
+	x.parent = y
+	updateSize(x)
+	updateSize(y)
+}
+
+// Insert adds a new record, keeping the tree balanced.
+func (t *rbStore[K, V]) Insert(key K, value V) {
+	n := &rbNode[K, V]{key: key, value: value, seq: t.seq, color: rbRed, size: 1}
+	t.seq++
+	t.index[key] = n
+
+	if t.root == nil {
+		n.color = rbBlack
+		t.root = n
+		t.leftmost = n
+		t.rightmost = n
+		return
+	}
+
+	cur := t.root
+	var parent *rbNode[K, V]
+	left := false
+	for cur != nil {
+		parent = cur
+		if rbLess(n, cur) {
+			cur = cur.left
+			left = true
+		} else {
+			cur = cur.right
+			left = false
+		}
+	}
+
+	n.parent = parent
+	if left {
+		parent.left = n
+	} else {
+		parent.right = n
+	}
+
+	for p := parent; p != nil; p = p.parent {
+		p.size++
+	}
+
+	if rbLess(n, t.leftmost) {
+		t.leftmost = n
+	}
+	if rbLess(t.rightmost, n) {
+		t.rightmost = n
+	}
+
+	t.insertFixup(n)
+}
+
+func (t *rbStore[K, V]) insertFixup(z *rbNode[K, V]) {
+	for z.parent != nil && z.parent.color == rbRed {
+		grandparent := z.parent.parent
+		if z.parent == grandparent.left {
+			uncle := grandparent.right
+			if colorOf(uncle) == rbRed {
+				z.parent.color = rbBlack
+				uncle.color = rbBlack
+				grandparent.color = rbRed
+				z = grandparent
+				continue
+			}
+			if z == z.parent.right {
+				z = z.parent
+				t.rotateLeft(z)
+			}
+			z.parent.color = rbBlack
+			z.parent.parent.color = rbRed
+			t.rotateRight(z.parent.parent)
+		} else {
+			uncle := grandparent.left
+			if colorOf(uncle) == rbRed {
+				z.parent.color = rbBlack
+				uncle.color = rbBlack
+				grandparent.color = rbRed
+				z = grandparent
+				continue
+			}
+			if z == z.parent.left {
+				z = z.parent
+				t.rotateRight(z)
+			}
+			z.parent.color = rbBlack
+			z.parent.parent.color = rbRed
+			t.rotateLeft(z.parent.parent)
+		}
+	}
+	t.root.color = rbBlack
+}
+
+func (t *rbStore[K, V]) transplant(u, v *rbNode[K, V]) {
+	switch {
+	case u.parent == nil:
+		t.root = v
+	case u == u.parent.left:
+		u.parent.left = v
+	default:
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+// fixSizes recomputes subtree sizes from n up to the root. It is called
+// after the tree shape has reached its final, post-deletion form (but
+// before any rebalancing rotations, which maintain sizes themselves).
+func (t *rbStore[K, V]) fixSizes(n *rbNode[K, V]) {
+	for ; n != nil; n = n.parent {
+		updateSize(n)
+	}
+}
+
+// Remove deletes the record for key.
+func (t *rbStore[K, V]) Remove(key K) {
+	z, ok := t.index[key]
+	if !ok {
+		return
+	}
+	delete(t.index, key)
+
+	y := z
+	yOriginalColor := y.color
+	var x, xParent *rbNode[K, V]
+
+	switch {
+	case z.left == nil:
+		x = z.right
+		xParent = z.parent
+		t.transplant(z, z.right)
+	case z.right == nil:
+		x = z.left
+		xParent = z.parent
+		t.transplant(z, z.left)
+	default:
+		y = minNode(z.right)
+		yOriginalColor = y.color
+		x = y.right
+		if y.parent == z {
+			xParent = y
+		} else {
+			xParent = y.parent
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.color = z.color
+	}
+
+	t.fixSizes(xParent)
+
+	if yOriginalColor == rbBlack {
+		t.deleteFixup(x, xParent)
+	}
+
+	if t.root != nil {
+		t.leftmost = minNode(t.root)
+		t.rightmost = maxNode(t.root)
+	} else {
+		t.leftmost, t.rightmost = nil, nil
+	}
+}
+
+func (t *rbStore[K, V]) deleteFixup(x, parent *rbNode[K, V]) {
+	for x != t.root && colorOf(x) == rbBlack && parent != nil {
+		if x == parent.left {
+			w := parent.right
+			if colorOf(w) == rbRed {
+				w.color = rbBlack
+				parent.color = rbRed
+				t.rotateLeft(parent)
+				w = parent.right
+			}
+			if colorOf(w.left) == rbBlack && colorOf(w.right) == rbBlack {
+				w.color = rbRed
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if colorOf(w.right) == rbBlack {
+				if w.left != nil {
+					w.left.color = rbBlack
+				}
+				w.color = rbRed
+				t.rotateRight(w)
+				w = parent.right
+			}
+			w.color = parent.color
+			parent.color = rbBlack
+			if w.right != nil {
+				w.right.color = rbBlack
+			}
+			t.rotateLeft(parent)
+			x = t.root
+			parent = nil
+		} else {
+			w := parent.left
+			if colorOf(w) == rbRed {
+				w.color = rbBlack
+				parent.color = rbRed
+				t.rotateRight(parent)
+				w = parent.left
+			}
+			if colorOf(w.right) == rbBlack && colorOf(w.left) == rbBlack {
+				w.color = rbRed
+				x = parent
+				parent = x.parent
+				continue
+			}
+			if colorOf(w.left) == rbBlack {
+				if w.right != nil {
+					w.right.color = rbBlack
+				}
+				w.color = rbRed
+				t.rotateLeft(w)
+				w = parent.left
+			}
+			w.color = parent.color
+			parent.color = rbBlack
+			if w.left != nil {
+				w.left.color = rbBlack
+			}
+			t.rotateRight(parent)
+			x = t.root
+			parent = nil
+		}
+	}
+	if x != nil {
+		x.color = rbBlack
+	}
+}
+
+// Get returns the value stored for key, if any.
+func (t *rbStore[K, V]) Get(key K) (V, bool) {
+	n, ok := t.index[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Len returns the number of stored records.
+func (t *rbStore[K, V]) Len() int {
+	return sizeOf(t.root)
+}
+
+// At returns the record at position i in value order via an
+// order-statistic select over subtree sizes.
+func (t *rbStore[K, V]) At(i int) Record[K, V] {
+	n := t.root
+	for n != nil {
+		left := sizeOf(n.left)
+		switch {
+		case i < left:
+			n = n.left
+		case i == left:
+			return Record[K, V]{n.key, n.value}
+		default:
+			i -= left + 1
+			n = n.right
+		}
+	}
+	panic("orderedlist: index out of range")
+}
+
+// GetLowest returns the record with the lowest value, if any.
+func (t *rbStore[K, V]) GetLowest() (Record[K, V], bool) {
+	if t.leftmost == nil {
+		return Record[K, V]{}, false
+	}
+	return Record[K, V]{t.leftmost.key, t.leftmost.value}, true
+}
+
+// GetHighest returns the record with the highest value, if any.
+func (t *rbStore[K, V]) GetHighest() (Record[K, V], bool) {
+	if t.rightmost == nil {
+		return Record[K, V]{}, false
+	}
+	return Record[K, V]{t.rightmost.key, t.rightmost.value}, true
+}
+
+// lowerBound returns the smallest node with a value >= value, or nil.
+func (t *rbStore[K, V]) lowerBound(value V) *rbNode[K, V] {
+	var result *rbNode[K, V]
+	for n := t.root; n != nil; {
+		if n.value >= value {
+			result = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return result
+}
+
+// upperBound returns the smallest node with a value > value, or nil.
+func (t *rbStore[K, V]) upperBound(value V) *rbNode[K, V] {
+	var result *rbNode[K, V]
+	for n := t.root; n != nil; {
+		if n.value > value {
+			result = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return result
+}
+
+// GetGreater returns the record with the smallest value strictly
+// greater than value, if any.
+func (t *rbStore[K, V]) GetGreater(value V) (Record[K, V], bool) {
+	n := t.upperBound(value)
+	if n == nil {
+		return Record[K, V]{}, false
+	}
+	return Record[K, V]{n.key, n.value}, true
+}
+
+// GetGreaterThanOrEqual returns the record with the smallest value
+// greater than or equal to value, if any.
+func (t *rbStore[K, V]) GetGreaterThanOrEqual(value V) (Record[K, V], bool) {
+	n := t.lowerBound(value)
+	if n == nil {
+		return Record[K, V]{}, false
+	}
+	return Record[K, V]{n.key, n.value}, true
+}
+
+// GetLower returns the record with the largest value strictly lower
+// than value, if any.
+func (t *rbStore[K, V]) GetLower(value V) (Record[K, V], bool) {
+	n := t.lowerBound(value)
+	if n == nil {
+		n = t.rightmost
+	} else {
+		n = predecessor(n)
+	}
+	if n == nil {
+		return Record[K, V]{}, false
+	}
+	return Record[K, V]{n.key, n.value}, true
+}
+
+// GetLowerThanOrEqual returns the record with the largest value lower
+// than or equal to value, if any.
+func (t *rbStore[K, V]) GetLowerThanOrEqual(value V) (Record[K, V], bool) {
+	n := t.upperBound(value)
+	if n == nil {
+		n = t.rightmost
+	} else {
+		n = predecessor(n)
+	}
+	if n == nil {
+		return Record[K, V]{}, false
+	}
+	return Record[K, V]{n.key, n.value}, true
+}
+
+// rangeBounds returns the first node in [lo, hi] (per inclusive) and the
+// node to stop before, in value order.
+func (t *rbStore[K, V]) rangeBounds(lo, hi V, inclusive bool) (*rbNode[K, V], *rbNode[K, V]) {
+	if inclusive {
+		return t.lowerBound(lo), t.upperBound(hi)
+	}
+	return t.upperBound(lo), t.lowerBound(hi)
+}
+
+// Range returns every record with a value between lo and hi, in value
+// order.
+func (t *rbStore[K, V]) Range(lo, hi V, inclusive bool) []Record[K, V] {
+	start, stop := t.rangeBounds(lo, hi, inclusive)
+
+	var out []Record[K, V]
+	for n := start; n != nil && n != stop; n = successor(n) {
+		out = append(out, Record[K, V]{n.key, n.value})
+	}
+	return out
+}
+
+// RangeFunc walks every record with a value between lo and hi, in value
+// order, calling fn for each until fn returns false.
+func (t *rbStore[K, V]) RangeFunc(lo, hi V, inclusive bool, fn func(Record[K, V]) bool) {
+	start, stop := t.rangeBounds(lo, hi, inclusive)
+
+	for n := start; n != nil && n != stop; n = successor(n) {
+		if !fn(Record[K, V]{n.key, n.value}) {
+			return
+		}
+	}
+}
+
+// Empty returns a new, empty red-black tree-backed store.
+func (t *rbStore[K, V]) Empty() Store[K, V] {
+	return newRBStore[K, V]()
+}
+
+// LoadSorted replaces all records with recs, re-inserting them in
+// order. recs must already be sorted in value order.
+func (t *rbStore[K, V]) LoadSorted(recs []Record[K, V]) {
+	*t = rbStore[K, V]{index: make(map[K]*rbNode[K, V])}
+	for _, rec := range recs {
+		t.Insert(rec.Key, rec.Value)
+	}
+}
+
+// All returns every record in value order via an in-order walk.
+func (t *rbStore[K, V]) All() []Record[K, V] {
+	out := make([]Record[K, V], 0, sizeOf(t.root))
+	var walk func(n *rbNode[K, V])
+	walk = func(n *rbNode[K, V]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		out = append(out, Record[K, V]{n.key, n.value})
+		walk(n.right)
+	}
+	walk(t.root)
+	return out
+}
@@ -0,0 +1,138 @@
+package orderedlist
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+// validateRBInvariants walks the whole tree and fails t if any red-black
+// or size invariant doesn't hold: root is black, a red node has only
+// black children, every root-to-leaf path has the same black-height, and
+// every node's size equals 1 + left.size + right.size.
+func validateRBInvariants[K comparable, V constraints.Ordered](t *testing.T, store *rbStore[K, V]) {
+	t.Helper()
+
+	if store.root == nil {
+		return
+	}
+	if store.root.color != rbBlack {
+		t.Fatalf("root is not black")
+	}
+
+	blackHeight := -1
+	var walk func(n *rbNode[K, V], blacks int)
+	walk = func(n *rbNode[K, V], blacks int) {
+		if n == nil {
+			if blackHeight == -1 {
+				blackHeight = blacks
+			} else if blacks != blackHeight {
+				t.Fatalf("unequal black-height: got %d, want %d", blacks, blackHeight)
+			}
+			return
+		}
+
+		if n.color == rbRed && (colorOf(n.left) == rbRed || colorOf(n.right) == rbRed) {
+			t.Fatalf("red node %v has a red child", n.key)
+		}
+
+		if want := 1 + sizeOf(n.left) + sizeOf(n.right); n.size != want {
+			t.Fatalf("size mismatch at key %v: got %d, want %d", n.key, n.size, want)
+		}
+
+		next := blacks
+		if n.color == rbBlack {
+			next++
+		}
+		walk(n.left, next)
+		walk(n.right, next)
+	}
+	walk(store.root, 0)
+}
+
+func TestRBTreeInvariantsUnderRandomInsertRemove(t *testing.T) {
+	store := newRBStore[string, uint64]()
+	present := make(map[string]uint64)
+	var keys []string
+
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 3000; i++ {
+		if len(keys) == 0 || rng.Intn(2) == 0 {
+			key := fmt.Sprintf("k%d", rng.Intn(500))
+			if _, exists := present[key]; exists {
+				continue
+			}
+			value := uint64(rng.Intn(100000))
+			store.Insert(key, value)
+			present[key] = value
+			keys = append(keys, key)
+		} else {
+			idx := rng.Intn(len(keys))
+			key := keys[idx]
+			store.Remove(key)
+			delete(present, key)
+			keys = append(keys[:idx], keys[idx+1:]...)
+		}
+
+		validateRBInvariants(t, store)
+
+		if store.Len() != len(present) {
+			t.Fatalf("Len() = %d, want %d", store.Len(), len(present))
+		}
+	}
+}
+
+func TestRBTreeOrderStatistic(t *testing.T) {
+	store := newRBStore[string, uint64]()
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < 500; i++ {
+		store.Insert(fmt.Sprintf("k%d", i), uint64(rng.Intn(100000)))
+	}
+
+	all := store.All()
+	if len(all) != store.Len() {
+		t.Fatalf("All() returned %d records, want %d", len(all), store.Len())
+	}
+
+	for i, want := range all {
+		if got := store.At(i); got != want {
+			t.Fatalf("At(%d) = %+v, want %+v", i, got, want)
+		}
+	}
+
+	for i := 1; i < len(all); i++ {
+		if all[i].Value < all[i-1].Value {
+			t.Fatalf("All() not sorted at index %d: %+v before %+v", i, all[i-1], all[i])
+		}
+	}
+}
+
+func TestRBTreeGetLowestHighestAfterRemovals(t *testing.T) {
+	store := newRBStore[string, uint64]()
+	for i, v := range []uint64{5, 3, 8, 1, 9, 2} {
+		store.Insert(fmt.Sprintf("k%d", i), v)
+	}
+
+	lowest, ok := store.GetLowest()
+	if !ok || lowest.Value != 1 {
+		t.Fatalf("GetLowest() = %+v, %v, want value 1", lowest, ok)
+	}
+	highest, ok := store.GetHighest()
+	if !ok || highest.Value != 9 {
+		t.Fatalf("GetHighest() = %+v, %v, want value 9", highest, ok)
+	}
+
+	store.Remove("k3") // value 1
+	store.Remove("k4") // value 9
+
+	lowest, ok = store.GetLowest()
+	if !ok || lowest.Value != 2 {
+		t.Fatalf("GetLowest() after removal = %+v, %v, want value 2", lowest, ok)
+	}
+	highest, ok = store.GetHighest()
+	if !ok || highest.Value != 8 {
+		t.Fatalf("GetHighest() after removal = %+v, %v, want value 8", highest, ok)
+	}
+}
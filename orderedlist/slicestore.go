@@ -0,0 +1,209 @@
+package orderedlist
+
+import (
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// sliceStore is the original O(n) backend: records kept in a single
+// value-ordered slice. It is fine for small lists and is what New() uses
+// by default.
+type sliceStore[K comparable, V constraints.Ordered] struct {
+	rec []Record[K, V]
+}
+
+func newSliceStore[K comparable, V constraints.Ordered]() *sliceStore[K, V] {
+	return &sliceStore[K, V]{}
+}
+
+// Insert inserts a key-value pair into the slice, keeping it sorted by value
+func (s *sliceStore[K, V]) Insert(key K, value V) {
+	index := s.getPosition(value)
+
+	if index == -1 {
+		// Append to the bottom of the list
+		s.rec = append(s.rec, Record[K, V]{key, value})
+	} else {
+		// Append accordingly
+		s.rec = append(s.rec, Record[K, V]{})
+		copy(s.rec[index+1:], s.rec[index:])
+		s.rec[index] = Record[K, V]{key, value}
+	}
+}
+
+// Get returns value of the provided key
+func (s *sliceStore[K, V]) Get(key K) (V, bool) {
+	index := s.getIndexByKey(key)
+	if index == -1 {
+		var zero V
+		return zero, false
+	}
+
+	return s.rec[index].Value, true
+}
+
+// Remove removes a key-value pair by key
+func (s *sliceStore[K, V]) Remove(key K) {
+	index := s.getIndexByKey(key)
+	if index == -1 {
+		return
+	}
+
+	s.rec = append(s.rec[:index], s.rec[index+1:]...)
+}
+
+// Len returns the number of stored records
+func (s *sliceStore[K, V]) Len() int {
+	return len(s.rec)
+}
+
+// At returns the record at position i in value order
+func (s *sliceStore[K, V]) At(i int) Record[K, V] {
+	return s.rec[i]
+}
+
+// GetLowest returns key-value pair with lowest value on the list
+func (s *sliceStore[K, V]) GetLowest() (Record[K, V], bool) {
+	if len(s.rec) == 0 {
+		return Record[K, V]{}, false
+	}
+
+	return s.rec[0], true
+}
+
+// GetHighest returns key-value pair with highest value on the list
+func (s *sliceStore[K, V]) GetHighest() (Record[K, V], bool) {
+	if len(s.rec) == 0 {
+		return Record[K, V]{}, false
+	}
+
+	return s.rec[len(s.rec)-1], true
+}
+
+// All returns every record in value order
+func (s *sliceStore[K, V]) All() []Record[K, V] {
+	return s.rec
+}
+
+// GetGreater returns the record with the smallest value strictly
+// greater than value, if any.
+func (s *sliceStore[K, V]) GetGreater(value V) (Record[K, V], bool) {
+	i := s.upperBound(value)
+	if i >= len(s.rec) {
+		return Record[K, V]{}, false
+	}
+	return s.rec[i], true
+}
+
+// GetGreaterThanOrEqual returns the record with the smallest value
+// greater than or equal to value, if any.
+func (s *sliceStore[K, V]) GetGreaterThanOrEqual(value V) (Record[K, V], bool) {
+	i := s.lowerBound(value)
+	if i >= len(s.rec) {
+		return Record[K, V]{}, false
+	}
+	return s.rec[i], true
+}
+
+// GetLower returns the record with the largest value strictly lower
+// than value, if any.
+func (s *sliceStore[K, V]) GetLower(value V) (Record[K, V], bool) {
+	i := s.lowerBound(value)
+	if i == 0 {
+		return Record[K, V]{}, false
+	}
+	return s.rec[i-1], true
+}
+
+// GetLowerThanOrEqual returns the record with the largest value lower
+// than or equal to value, if any.
+func (s *sliceStore[K, V]) GetLowerThanOrEqual(value V) (Record[K, V], bool) {
+	i := s.upperBound(value)
+	if i == 0 {
+		return Record[K, V]{}, false
+	}
+	return s.rec[i-1], true
+}
+
+// Range returns every record with a value between lo and hi, in value
+// order.
+func (s *sliceStore[K, V]) Range(lo, hi V, inclusive bool) []Record[K, V] {
+	start, end := s.bounds(lo, hi, inclusive)
+	if start >= end {
+		return nil
+	}
+
+	out := make([]Record[K, V], end-start)
+	copy(out, s.rec[start:end])
+	return out
+}
+
+// RangeFunc walks every record with a value between lo and hi, in value
+// order, calling fn for each until fn returns false.
+func (s *sliceStore[K, V]) RangeFunc(lo, hi V, inclusive bool, fn func(Record[K, V]) bool) {
+	start, end := s.bounds(lo, hi, inclusive)
+	for i := start; i < end; i++ {
+		if !fn(s.rec[i]) {
+			return
+		}
+	}
+}
+
+func (s *sliceStore[K, V]) bounds(lo, hi V, inclusive bool) (int, int) {
+	if inclusive {
+		return s.lowerBound(lo), s.upperBound(hi)
+	}
+	return s.upperBound(lo), s.lowerBound(hi)
+}
+
+// lowerBound returns the index of the first record with a value >= value
+func (s *sliceStore[K, V]) lowerBound(value V) int {
+	return sort.Search(len(s.rec), func(i int) bool {
+		return s.rec[i].Value >= value
+	})
+}
+
+// upperBound returns the index of the first record with a value > value
+func (s *sliceStore[K, V]) upperBound(value V) int {
+	return sort.Search(len(s.rec), func(i int) bool {
+		return s.rec[i].Value > value
+	})
+}
+
+// Empty returns a new, empty slice-backed store.
+func (s *sliceStore[K, V]) Empty() Store[K, V] {
+	return newSliceStore[K, V]()
+}
+
+// LoadSorted replaces the slice with a copy of recs.
+func (s *sliceStore[K, V]) LoadSorted(recs []Record[K, V]) {
+	s.rec = append([]Record[K, V](nil), recs...)
+}
+
+// getPosition returns proper position (index) to be placed into struct.
+// Ties go after existing records with the same value, so records with
+// equal values keep oldest-first order, matching the rbStore backend's
+// (value, seq) tie-break.
+func (s *sliceStore[K, V]) getPosition(value V) int {
+	index := -1
+	for i, record := range s.rec {
+		if value < record.Value {
+			index = i
+			break
+		}
+	}
+
+	return index
+}
+
+// getIndexByKey returns index of key-value pair in array by key, or -1
+func (s *sliceStore[K, V]) getIndexByKey(key K) int {
+	for i, record := range s.rec {
+		if record.Key == key {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,69 @@
+package orderedlist
+
+import "golang.org/x/exp/constraints"
+
+// Store is the storage backend behind an OrderedListG. It keeps records
+// ordered by value and exposes key lookup, so different backends can
+// trade off between simplicity and asymptotic complexity.
+type Store[K comparable, V constraints.Ordered] interface {
+	// Insert adds a new record. The caller guarantees key is not
+	// already present.
+	Insert(key K, value V)
+
+	// Get returns the value stored for key, if any.
+	Get(key K) (V, bool)
+
+	// Remove deletes the record for key. The caller guarantees key is
+	// present.
+	Remove(key K)
+
+	// Len returns the number of stored records.
+	Len() int
+
+	// At returns the record at position i in value order, where i is
+	// in [0, Len()).
+	At(i int) Record[K, V]
+
+	// GetLowest returns the record with the lowest value, if any.
+	GetLowest() (Record[K, V], bool)
+
+	// GetHighest returns the record with the highest value, if any.
+	GetHighest() (Record[K, V], bool)
+
+	// All returns every record in value order.
+	All() []Record[K, V]
+
+	// GetGreater returns the record with the smallest value strictly
+	// greater than value, if any.
+	GetGreater(value V) (Record[K, V], bool)
+
+	// GetGreaterThanOrEqual returns the record with the smallest value
+	// greater than or equal to value, if any.
+	GetGreaterThanOrEqual(value V) (Record[K, V], bool)
+
+	// GetLower returns the record with the largest value strictly
+	// lower than value, if any.
+	GetLower(value V) (Record[K, V], bool)
+
+	// GetLowerThanOrEqual returns the record with the largest value
+	// lower than or equal to value, if any.
+	GetLowerThanOrEqual(value V) (Record[K, V], bool)
+
+	// Range returns every record with a value between lo and hi, in
+	// value order. When inclusive is true both endpoints are included;
+	// otherwise both are excluded.
+	Range(lo, hi V, inclusive bool) []Record[K, V]
+
+	// RangeFunc walks every record with a value between lo and hi, in
+	// value order, calling fn for each until fn returns false.
+	RangeFunc(lo, hi V, inclusive bool, fn func(Record[K, V]) bool)
+
+	// Empty returns a new, empty instance of the same concrete backend
+	// type, for rebuilding a list without changing its backend.
+	Empty() Store[K, V]
+
+	// LoadSorted replaces all records with recs in one pass, without
+	// the per-record bookkeeping Insert does. recs must already be
+	// sorted in value order with no duplicate keys.
+	LoadSorted(recs []Record[K, V])
+}
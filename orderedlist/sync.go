@@ -0,0 +1,234 @@
+package orderedlist
+
+import (
+	"sync"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// SyncOrderedListG wraps an OrderedListG with a sync.RWMutex, making it
+// safe for concurrent use. Reads take RLock; writes take Lock.
+type SyncOrderedListG[K comparable, V constraints.Ordered] struct {
+	mu   sync.RWMutex
+	list OrderedListG[K, V]
+}
+
+// SyncOrderedList is the string-key, uint64-value instantiation of
+// SyncOrderedListG.
+type SyncOrderedList = SyncOrderedListG[string, uint64]
+
+// NewSync returns a new (empty), concurrency-safe ordered list backed by
+// a plain slice.
+func NewSync() *SyncOrderedList {
+	return NewSyncG[string, uint64]()
+}
+
+// NewSyncTree returns a new (empty), concurrency-safe ordered list
+// backed by a red-black tree.
+func NewSyncTree() *SyncOrderedList {
+	return NewSyncTreeG[string, uint64]()
+}
+
+// NewSyncG returns a new (empty), concurrency-safe, slice-backed generic
+// ordered list.
+func NewSyncG[K comparable, V constraints.Ordered]() *SyncOrderedListG[K, V] {
+	return NewSyncFromG(NewG[K, V]())
+}
+
+// NewSyncTreeG returns a new (empty), concurrency-safe, tree-backed
+// generic ordered list.
+func NewSyncTreeG[K comparable, V constraints.Ordered]() *SyncOrderedListG[K, V] {
+	return NewSyncFromG(NewTreeG[K, V]())
+}
+
+// NewSyncFromG wraps an existing OrderedListG with a mutex, letting
+// callers pick any backend (including NewWithBackendG).
+func NewSyncFromG[K comparable, V constraints.Ordered](list OrderedListG[K, V]) *SyncOrderedListG[K, V] {
+	return &SyncOrderedListG[K, V]{list: list}
+}
+
+// Insert inserts a key-value pair into the struct
+func (sl *SyncOrderedListG[K, V]) Insert(key K, value V) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	return sl.list.Insert(key, value)
+}
+
+// Get returns value of the provided key
+func (sl *SyncOrderedListG[K, V]) Get(key K) (V, error) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.Get(key)
+}
+
+// GetLowest returns the value of the record with the lowest value on
+// the list, or false if the list is empty.
+func (sl *SyncOrderedListG[K, V]) GetLowest() (V, bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.GetLowest()
+}
+
+// GetHighest returns the value of the record with the highest value on
+// the list, or false if the list is empty.
+func (sl *SyncOrderedListG[K, V]) GetHighest() (V, bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.GetHighest()
+}
+
+// At returns the record at position i in value order, where i is in
+// [0, Len()).
+func (sl *SyncOrderedListG[K, V]) At(i int) Record[K, V] {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.At(i)
+}
+
+// Len returns the number of key-value pairs on the list
+func (sl *SyncOrderedListG[K, V]) Len() int {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.Len()
+}
+
+// Remove removes a key-value pair by key
+func (sl *SyncOrderedListG[K, V]) Remove(key K) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	return sl.list.Remove(key)
+}
+
+// Update updates value by key
+func (sl *SyncOrderedListG[K, V]) Update(key K, value V) error {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	return sl.list.Update(key, value)
+}
+
+// GetGreater returns the record with the smallest value strictly
+// greater than value, if any.
+func (sl *SyncOrderedListG[K, V]) GetGreater(value V) (Record[K, V], bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.GetGreater(value)
+}
+
+// GetGreaterThanOrEqual returns the record with the smallest value
+// greater than or equal to value, if any.
+func (sl *SyncOrderedListG[K, V]) GetGreaterThanOrEqual(value V) (Record[K, V], bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.GetGreaterThanOrEqual(value)
+}
+
+// GetLower returns the record with the largest value strictly lower
+// than value, if any.
+func (sl *SyncOrderedListG[K, V]) GetLower(value V) (Record[K, V], bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.GetLower(value)
+}
+
+// GetLowerThanOrEqual returns the record with the largest value lower
+// than or equal to value, if any.
+func (sl *SyncOrderedListG[K, V]) GetLowerThanOrEqual(value V) (Record[K, V], bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.GetLowerThanOrEqual(value)
+}
+
+// Range returns every record with a value between lo and hi, in value
+// order.
+func (sl *SyncOrderedListG[K, V]) Range(lo, hi V, inclusive bool) []Record[K, V] {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.Range(lo, hi, inclusive)
+}
+
+// RangeFunc walks every record with a value between lo and hi, in value
+// order, calling fn for each until fn returns false.
+func (sl *SyncOrderedListG[K, V]) RangeFunc(lo, hi V, inclusive bool, fn func(Record[K, V]) bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	sl.list.RangeFunc(lo, hi, inclusive, fn)
+}
+
+// Oldest returns the record that has been on the list the longest, or
+// nil if the list is empty.
+func (sl *SyncOrderedListG[K, V]) Oldest() *Record[K, V] {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.Oldest()
+}
+
+// Newest returns the most recently inserted record, or nil if the list
+// is empty.
+func (sl *SyncOrderedListG[K, V]) Newest() *Record[K, V] {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	return sl.list.Newest()
+}
+
+// IterateInsertionOrder walks every record in insertion order, oldest
+// first, calling fn for each until fn returns false.
+func (sl *SyncOrderedListG[K, V]) IterateInsertionOrder(fn func(Record[K, V]) bool) {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	sl.list.IterateInsertionOrder(fn)
+}
+
+// Snapshot returns a copy of every record in value order, taken under
+// RLock so callers can range over it without holding the list's lock.
+func (sl *SyncOrderedListG[K, V]) Snapshot() []Record[K, V] {
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+
+	out := make([]Record[K, V], sl.list.Len())
+	for i := range out {
+		out[i] = sl.list.At(i)
+	}
+	return out
+}
+
+// Merge merges source into the receiver. Source is left unchanged.
+// Both lists' mutexes are locked in a deterministic address order, so
+// two goroutines merging each other's lists concurrently cannot
+// deadlock.
+func (sl *SyncOrderedListG[K, V]) Merge(source *SyncOrderedListG[K, V]) {
+	if sl == source {
+		return
+	}
+
+	if uintptr(unsafe.Pointer(sl)) < uintptr(unsafe.Pointer(source)) {
+		sl.mu.Lock()
+		defer sl.mu.Unlock()
+		source.mu.RLock()
+		defer source.mu.RUnlock()
+	} else {
+		source.mu.RLock()
+		defer source.mu.RUnlock()
+		sl.mu.Lock()
+		defer sl.mu.Unlock()
+	}
+
+	sl.list.Merge(&source.list)
+}
@@ -0,0 +1,74 @@
+package orderedlist
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSyncOrderedListConcurrentInsert(t *testing.T) {
+	sl := NewSync()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := sl.Insert(fmt.Sprintf("k%d", i), uint64(i)); err != nil {
+				t.Errorf("Insert: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if sl.Len() != 100 {
+		t.Fatalf("Len() = %d, want 100", sl.Len())
+	}
+}
+
+// TestSyncOrderedListMergeDoesNotDeadlock merges two lists into each
+// other concurrently, which only completes if Merge's lock ordering is
+// deadlock-free.
+func TestSyncOrderedListMergeDoesNotDeadlock(t *testing.T) {
+	a := NewSync()
+	b := NewSync()
+	a.Insert("a1", 1)
+	b.Insert("b1", 2)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		a.Merge(b)
+	}()
+	go func() {
+		defer wg.Done()
+		b.Merge(a)
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Merge deadlocked")
+	}
+}
+
+func TestSyncOrderedListSnapshotIsACopy(t *testing.T) {
+	sl := NewSync()
+	sl.Insert("a", 1)
+	sl.Insert("b", 2)
+
+	snap := sl.Snapshot()
+	sl.Insert("c", 3)
+
+	if len(snap) != 2 {
+		t.Fatalf("Snapshot() len = %d, want 2 (unaffected by later Insert)", len(snap))
+	}
+}